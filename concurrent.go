@@ -19,6 +19,24 @@ func (e *Concurrent) Add(errs ...error) {
 	e.errors = append(e.errors, errs...)
 }
 
+// errorsSnapshot returns a copy of the errors currently held by e, safe to
+// range over without holding e's mutex.
+func (e *Concurrent) errorsSnapshot() []error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	errs := make([]error, len(e.errors))
+	copy(errs, e.errors)
+	return errs
+}
+
+// Unwrap returns a snapshot of the errors collected so far, so that
+// errors.Is and errors.As (which understand Unwrap() []error as of Go
+// 1.20) can walk them if a caller embeds *Concurrent in its own error
+// type.
+func (e *Concurrent) Unwrap() []error {
+	return e.errorsSnapshot()
+}
+
 // Err bundles the errors in the Concurrent slice together and returns
 // a single error.  If there are no errors in the Concurrent slice,
 // a nil error is returned.
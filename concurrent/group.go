@@ -0,0 +1,99 @@
+// Package concurrent provides errgroup-style ergonomics for launching
+// Goroutines and collecting their errors, built on top of
+// github.com/skillian/errors' aggregate-error semantics.
+package concurrent
+
+import (
+	"context"
+	"sync"
+
+	"github.com/skillian/errors"
+)
+
+// Group runs functions concurrently with Go and collects their errors,
+// returning them from Wait as a single error via errors.Aggregate.
+//
+// The zero value of Group is usable and runs its Goroutines with no
+// associated context and no concurrency limit.
+type Group struct {
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	cancelOnFirstError bool
+	sem                chan struct{}
+}
+
+// WithContext returns a new Group and an associated context derived from
+// ctx.  The derived context is cancelled when Wait returns, and sooner if
+// the Group was configured with CancelOnFirstError(true) and a Goroutine
+// started with Go returns a non-nil error.
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{ctx: ctx, cancel: cancel}, ctx
+}
+
+// CancelOnFirstError configures g so that its context (see WithContext) is
+// cancelled as soon as any Goroutine started with Go returns a non-nil
+// error, instead of waiting for every Goroutine to finish.  It returns g
+// so calls can be chained.
+func (g *Group) CancelOnFirstError(cancel bool) *Group {
+	g.cancelOnFirstError = cancel
+	return g
+}
+
+// Limit caps the number of Goroutines started with Go that may run at
+// once, blocking further calls to Go until a running Goroutine finishes.
+// A limit of 0 (the default) means unlimited.  It returns g so calls can
+// be chained.
+func (g *Group) Limit(n int) *Group {
+	if n > 0 {
+		g.sem = make(chan struct{}, n)
+	} else {
+		g.sem = nil
+	}
+	return g
+}
+
+// Go starts f in its own Goroutine, passing it g's context (or
+// context.Background if g wasn't created with WithContext).  Any error f
+// returns is collected and included in Wait's result.
+func (g *Group) Go(f func(ctx context.Context) error) {
+	ctx := g.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+		if err := f(ctx); err != nil {
+			g.mu.Lock()
+			g.errs = append(g.errs, err)
+			g.mu.Unlock()
+			if g.cancelOnFirstError && g.cancel != nil {
+				g.cancel()
+			}
+		}
+	}()
+}
+
+// Wait blocks until every Goroutine started with Go has returned, then
+// returns errors.Aggregate of their errors (nil if none failed).
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return errors.Aggregate(g.errs...)
+}
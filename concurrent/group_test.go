@@ -0,0 +1,76 @@
+package concurrent_test
+
+import (
+	"context"
+	stderrors "errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/skillian/errors/concurrent"
+)
+
+func TestGroupWaitNoErrors(t *testing.T) {
+	t.Parallel()
+	g := &concurrent.Group{}
+	for i := 0; i < 4; i++ {
+		g.Go(func(ctx context.Context) error { return nil })
+	}
+	if err := g.Wait(); err != nil {
+		t.Errorf("Wait should be nil when nothing failed, got: %v", err)
+	}
+}
+
+func TestGroupWaitAggregatesErrors(t *testing.T) {
+	t.Parallel()
+	errA := stderrors.New("a")
+	errB := stderrors.New("b")
+	g := &concurrent.Group{}
+	g.Go(func(ctx context.Context) error { return errA })
+	g.Go(func(ctx context.Context) error { return errB })
+	g.Go(func(ctx context.Context) error { return nil })
+	err := g.Wait()
+	if err == nil {
+		t.Fatal("Wait should return an aggregate error")
+	}
+	if !stderrors.Is(err, errA) || !stderrors.Is(err, errB) {
+		t.Errorf("Wait's result should wrap both failures, got: %v", err)
+	}
+}
+
+func TestGroupCancelOnFirstError(t *testing.T) {
+	t.Parallel()
+	errBoom := stderrors.New("boom")
+	g, ctx := concurrent.WithContext(context.Background())
+	g.CancelOnFirstError(true)
+	g.Go(func(ctx context.Context) error { return errBoom })
+	_ = g.Wait()
+	select {
+	case <-ctx.Done():
+	default:
+		t.Error("context should be cancelled after a failing Goroutine when CancelOnFirstError(true)")
+	}
+}
+
+func TestGroupLimit(t *testing.T) {
+	t.Parallel()
+	g := &concurrent.Group{}
+	g.Limit(1)
+	var running int32
+	var maxRunning int32
+	for i := 0; i < 8; i++ {
+		g.Go(func(ctx context.Context) error {
+			n := atomic.AddInt32(&running, 1)
+			if n > atomic.LoadInt32(&maxRunning) {
+				atomic.StoreInt32(&maxRunning, n)
+			}
+			atomic.AddInt32(&running, -1)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait returned an unexpected error: %v", err)
+	}
+	if maxRunning > 1 {
+		t.Errorf("Limit(1) should cap concurrency at 1, observed %d", maxRunning)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"path"
 	"runtime"
 	"strings"
+	"sync"
 )
 
 const pathSep = string(byte(os.PathSeparator))
@@ -26,6 +27,25 @@ var (
 	}(path.Clean(os.Getenv("GOPATH")))
 )
 
+// StackDepth is the maximum number of stack frames CreateError captures.
+// Set it to 0 to disable stack capture entirely, e.g. on latency-critical
+// paths where the cost of even the lightweight capture below matters.
+var StackDepth = 32
+
+// inlinePCs is the number of program counters Error stores inline, without
+// a heap allocation.  Stacks deeper than this overflow into pcsOverflow.
+const inlinePCs = 16
+
+// pcsScratchPool holds reusable []uintptr buffers used while capturing a
+// stack in setErrorPCs, so that CreateError doesn't allocate one on every
+// call just to throw it away once the frames are copied into an Error.
+var pcsScratchPool = sync.Pool{
+	New: func() interface{} {
+		s := make([]uintptr, 64)
+		return &s
+	},
+}
+
 // Error bundles a builtin error with its causing error (Cause) or the error
 // that was being handled at the time that the Err error occurred (Context).
 //
@@ -41,9 +61,16 @@ type Error struct {
 	// was generated
 	Context error
 
-	// pcs holds a slice of program counters that can be turned into a stack
-	// trace.
-	pcs []uintptr
+	// pcs holds the first inlinePCs program counters of the captured
+	// stack, avoiding a heap allocation for the common case of a shallow
+	// stack.  pcsLen is the number of program counters stored in pcs, at
+	// most inlinePCs; any further ones are in pcsOverflow.
+	pcs    [inlinePCs]uintptr
+	pcsLen int
+
+	// pcsOverflow holds any program counters beyond the first inlinePCs,
+	// for stacks deeper than the inline array can hold.
+	pcsOverflow []uintptr
 }
 
 // Cause gets the root cause of the given error.  If the error is an
@@ -87,7 +114,7 @@ func New(text string) error {
 // isn't discarded.
 func WrapDeferred(pe *error, deferred func() error) {
 	if err := deferred(); err != nil {
-		if pe == nil {
+		if *pe == nil {
 			*pe = err
 		} else {
 			*pe = CreateError(err, nil, *pe, 0)
@@ -160,6 +187,24 @@ func (e Error) As(target interface{}) bool {
 	return As(e.Err, target) || (e.Cause != nil && As(e.Cause, target)) || (e.Context != nil && As(e.Context, target))
 }
 
+// Unwrap returns every non-nil one of Err, Cause, and Context (Err first)
+// so the standard library's errors.Is and errors.As, which understand
+// Unwrap() []error as of Go 1.20, can walk the whole graph even without
+// going through e's own As/Is.
+func (e Error) Unwrap() []error {
+	errs := make([]error, 0, 3)
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	if e.Cause != nil {
+		errs = append(errs, e.Cause)
+	}
+	if e.Context != nil {
+		errs = append(errs, e.Context)
+	}
+	return errs
+}
+
 func (e Error) Is(err error) bool {
 	if e2, ok := err.(Error); ok {
 		return Is(e.Err, e2.Err) && ((e.Cause == nil && e2.Cause == nil) || (e.Cause != nil && e2.Cause != nil && Is(e.Cause, e2.Cause))) && ((e.Context == nil && e2.Context == nil) || (e.Context != nil && e2.Context != nil && Is(e.Context, e2.Context)))
@@ -167,30 +212,77 @@ func (e Error) Is(err error) bool {
 	return Is(e.Err, err) || (e.Cause != nil && Is(e.Cause, err)) || (e.Context != nil && Is(e.Context, err))
 }
 
+// setErrorPCs captures up to StackDepth program counters into e, starting
+// at the frame specified by skip.  The capture itself can't be deferred
+// (the stack will have unwound by the time it's needed), but resolving the
+// program counters into symbols is left to Frames/formatStackTrace, which
+// run only when the stack trace is actually requested.
 func setErrorPCs(skip int, e *Error) {
-	var cache [32]uintptr
-	pcs := cache[:]
-	for count := 0; ; {
-		count += runtime.Callers(skip+2+count, pcs[count:])
-		if count < len(pcs) {
-			pcs = pcs[:count]
+	depth := StackDepth
+	if depth <= 0 {
+		return
+	}
+	bufp := pcsScratchPool.Get().(*[]uintptr)
+	defer pcsScratchPool.Put(bufp)
+	buf := *bufp
+	if cap(buf) < depth {
+		buf = make([]uintptr, depth)
+	}
+	buf = buf[:depth]
+	n := runtime.Callers(skip+2, buf)
+	buf = buf[:n]
+	if n <= inlinePCs {
+		copy(e.pcs[:], buf)
+		e.pcsLen = n
+		return
+	}
+	e.pcsLen = inlinePCs
+	copy(e.pcs[:], buf[:inlinePCs])
+	e.pcsOverflow = append([]uintptr(nil), buf[inlinePCs:]...)
+}
+
+// allPCs returns the full slice of program counters captured for e, inline
+// and overflow combined, or nil if no stack was captured.
+func (e Error) allPCs() []uintptr {
+	if e.pcsLen == 0 {
+		return nil
+	}
+	if len(e.pcsOverflow) == 0 {
+		return e.pcs[:e.pcsLen]
+	}
+	all := make([]uintptr, 0, e.pcsLen+len(e.pcsOverflow))
+	all = append(all, e.pcs[:e.pcsLen]...)
+	all = append(all, e.pcsOverflow...)
+	return all
+}
+
+// Frames resolves e's captured program counters into runtime.Frame values,
+// so callers can inspect the stack programmatically without going through
+// string formatting first.  It returns nil if e has no captured stack.
+func (e Error) Frames() []runtime.Frame {
+	pcs := e.allPCs()
+	if len(pcs) == 0 {
+		return nil
+	}
+	out := make([]runtime.Frame, 0, len(pcs))
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		out = append(out, frame)
+		if !more {
 			break
 		}
-		pcs = append(pcs, 0)
-		pcs = pcs[:cap(pcs)]
 	}
-	e.pcs = make([]uintptr, len(pcs))
-	copy(e.pcs, pcs)
+	return out
 }
 
 func formatStackTrace(e Error) string {
-	if e.pcs == nil {
+	frames := e.Frames()
+	if len(frames) == 0 {
 		return ""
 	}
-	formattedFrames := make([]string, 0, len(e.pcs))
-	frames := runtime.CallersFrames(e.pcs)
-	for {
-		frame, more := frames.Next()
+	formattedFrames := make([]string, 0, len(frames))
+	for _, frame := range frames {
 		file := frame.File
 		if strings.HasPrefix(file, goPath) {
 			file = file[len(goPath):]
@@ -200,9 +292,6 @@ func formatStackTrace(e Error) string {
 			frame.Function,
 			file,
 			frame.Line))
-		if !more {
-			break
-		}
 	}
 	return strings.Join(formattedFrames, "\n")
 }
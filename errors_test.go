@@ -73,6 +73,30 @@ func TestWithStackTrace(t *testing.T) {
 	}
 }
 
+func BenchmarkErrorf(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = skerrors.Errorf("boom: %d", i)
+	}
+}
+
+func BenchmarkErrorfWithCause(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = skerrors.ErrorfWithCause(ErrHelloWorld, "boom: %d", i)
+	}
+}
+
+func BenchmarkErrorfNoStack(b *testing.B) {
+	old := skerrors.StackDepth
+	skerrors.StackDepth = 0
+	defer func() { skerrors.StackDepth = old }()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = skerrors.Errorf("boom: %d", i)
+	}
+}
+
 func findDiffIndex(a, b string) int {
 	for i, r := range []byte(a) {
 		if b[i] != r {
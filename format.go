@@ -0,0 +1,181 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Format implements fmt.Formatter.  %s and %v print the same text as
+// Error; %q prints that text quoted; %+v additionally appends the
+// resolved stack trace and recursively formats Cause and Context.
+func (e Error) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			io.WriteString(f, e.Err.Error())
+			if trace := formatStackTrace(e); trace != "" {
+				io.WriteString(f, "\n")
+				io.WriteString(f, trace)
+			}
+			if e.Cause != nil {
+				fmt.Fprintf(f, "\nCause:  %+v", e.Cause)
+			}
+			if e.Context != nil {
+				fmt.Fprintf(f, "\nContext:  %+v", e.Context)
+			}
+			return
+		}
+		io.WriteString(f, e.Error())
+	case 's':
+		io.WriteString(f, e.Error())
+	case 'q':
+		fmt.Fprintf(f, "%q", e.Error())
+	default:
+		fmt.Fprintf(f, "%%!%c(errors.Error=%s)", verb, e.Error())
+	}
+}
+
+// Format implements fmt.Formatter.  %+v formats every collected error with
+// %+v, indented under a summary line; %s, %v, and %q behave as they do for
+// Error.
+func (es Errors) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			fmt.Fprintf(f, "%d errors:", len(es.errors))
+			for _, err := range es.errors {
+				io.WriteString(f, "\n  ")
+				fmt.Fprintf(f, "%+v", err)
+			}
+			return
+		}
+		io.WriteString(f, es.Error())
+	case 's':
+		io.WriteString(f, es.Error())
+	case 'q':
+		fmt.Fprintf(f, "%q", es.Error())
+	default:
+		fmt.Fprintf(f, "%%!%c(errors.Errors=%s)", verb, es.Error())
+	}
+}
+
+// Format implements fmt.Formatter.  %+v formats every collected error with
+// %+v, indented under a summary line; %s, %v, and %q print the same text
+// as Err (or "no errors" if none were collected).
+func (e *Concurrent) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			errs := e.errorsSnapshot()
+			fmt.Fprintf(f, "%d errors:", len(errs))
+			for _, err := range errs {
+				io.WriteString(f, "\n  ")
+				fmt.Fprintf(f, "%+v", err)
+			}
+			return
+		}
+		io.WriteString(f, e.concurrentString())
+	case 's':
+		io.WriteString(f, e.concurrentString())
+	case 'q':
+		fmt.Fprintf(f, "%q", e.concurrentString())
+	default:
+		fmt.Fprintf(f, "%%!%c(errors.Concurrent=%s)", verb, e.concurrentString())
+	}
+}
+
+func (e *Concurrent) concurrentString() string {
+	if err := e.Err(); err != nil {
+		return err.Error()
+	}
+	return "no errors"
+}
+
+// Format implements fmt.Formatter for Message.  %s and %v print the
+// formatted message; %q prints it quoted; %+v is the same as %v because a
+// Message carries no stack, cause, or context of its own.
+func (m Message) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		io.WriteString(f, m.Error())
+	case 'q':
+		fmt.Fprintf(f, "%q", m.Error())
+	default:
+		fmt.Fprintf(f, "%%!%c(errors.Message=%s)", verb, m.Error())
+	}
+}
+
+// errorJSON is the wire representation used by Error.MarshalJSON and
+// Error.UnmarshalJSON.
+type errorJSON struct {
+	Error     string      `json:"error"`
+	Cause     string      `json:"cause,omitempty"`
+	Context   string      `json:"context,omitempty"`
+	Stack     []frameJSON `json:"stack,omitempty"`
+	Aggregate []string    `json:"aggregate,omitempty"`
+}
+
+// frameJSON is the wire representation of a single runtime.Frame.
+type frameJSON struct {
+	Func string `json:"func"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// MarshalJSON implements json.Marshaler so structured loggers can consume
+// errors from this package without calling Error and re-parsing its
+// string form.
+func (e Error) MarshalJSON() ([]byte, error) {
+	ej := errorJSON{Error: e.Err.Error()}
+	if e.Cause != nil {
+		ej.Cause = e.Cause.Error()
+	}
+	if e.Context != nil {
+		ej.Context = e.Context.Error()
+	}
+	if es, ok := e.Err.(Errors); ok {
+		ej.Aggregate = make([]string, len(es.errors))
+		for i, err := range es.errors {
+			ej.Aggregate[i] = err.Error()
+		}
+	}
+	for _, frame := range e.Frames() {
+		ej.Stack = append(ej.Stack, frameJSON{
+			Func: frame.Function,
+			File: frame.File,
+			Line: frame.Line,
+		})
+	}
+	return json.Marshal(ej)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, reconstructing a plain *Error
+// from JSON produced by MarshalJSON.  Stack frames are not restored as
+// program counters (there is no way to do that across process
+// boundaries); callers that need them should inspect the JSON directly.
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var ej errorJSON
+	if err := json.Unmarshal(data, &ej); err != nil {
+		return err
+	}
+	e.pcsLen = 0
+	e.pcsOverflow = nil
+	e.Err = New(ej.Error)
+	if len(ej.Aggregate) > 0 {
+		errs := make([]error, len(ej.Aggregate))
+		for i, s := range ej.Aggregate {
+			errs[i] = New(s)
+		}
+		e.Err = Errors{errors: errs}
+	}
+	e.Cause = nil
+	if ej.Cause != "" {
+		e.Cause = New(ej.Cause)
+	}
+	e.Context = nil
+	if ej.Context != "" {
+		e.Context = New(ej.Context)
+	}
+	return nil
+}
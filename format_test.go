@@ -0,0 +1,50 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	skerrors "github.com/skillian/errors"
+)
+
+func TestFormatPlusV(t *testing.T) {
+	t.Parallel()
+	e := skerrors.CreateError(ErrHelloWorld, ErrHelloWorld, nil, 0)
+	s := fmt.Sprintf("%+v", e)
+	if !strings.HasPrefix(s, HelloWorld) {
+		t.Errorf("%%+v should start with the message, got: %q", s)
+	}
+	if !strings.Contains(s, "Cause:") {
+		t.Errorf("%%+v should include the Cause, got: %q", s)
+	}
+}
+
+func TestFormatQ(t *testing.T) {
+	t.Parallel()
+	e := skerrors.Error{Err: ErrHelloWorld}
+	s := fmt.Sprintf("%q", e)
+	if s != fmt.Sprintf("%q", e.Error()) {
+		t.Errorf("%%q should quote Error(), got: %s", s)
+	}
+}
+
+func TestErrorMarshalJSON(t *testing.T) {
+	t.Parallel()
+	e := skerrors.Error{Err: ErrHelloWorld, Cause: ErrHelloWorld}
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	var round skerrors.Error
+	if err := json.Unmarshal(data, &round); err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+	if round.Err.Error() != HelloWorld {
+		t.Errorf("round-tripped Err should be %q, got %q", HelloWorld, round.Err.Error())
+	}
+	if round.Cause.Error() != HelloWorld {
+		t.Errorf("round-tripped Cause should be %q, got %q", HelloWorld, round.Cause.Error())
+	}
+}
@@ -0,0 +1,202 @@
+// Package grpcerr bridges github.com/skillian/errors error values and
+// google.golang.org/grpc/status.Status, so servers built on this module
+// can return errors over gRPC and clients can reconstruct them on the
+// other side.
+//
+// Deviation from a generated message: each error is attached to the
+// Status as a detail shaped like message/cause/context/stack, but rather
+// than a protoc-generated ErrorDetail type, it's encoded as a
+// structpb.Struct with those same keys ("message", "cause", "context",
+// "stack", and "func"/"file"/"line" per stack frame).  That keeps this
+// package dependency-free of a generated .pb.go and its protoc toolchain
+// requirement, at the cost of a stringly-typed wire contract instead of a
+// typed one; ToStatus and FromStatus are the only code that needs to agree
+// on the field names, and they're defined side by side below.
+package grpcerr
+
+import (
+	"runtime"
+
+	legacyproto "github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	skerrors "github.com/skillian/errors"
+)
+
+// ToStatus converts err into a *status.Status.  UnexpectedType errors map
+// to codes.InvalidArgument, errors marked retryable (see
+// skerrors.IsRetryable) map to codes.Unavailable, aggregate skerrors.Errors
+// map to codes.Internal, and everything else maps to codes.Unknown.  Each
+// error of an aggregate (or err itself, if it isn't an aggregate) is
+// attached to the returned status as a detail so FromStatus can
+// reconstruct it.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+	code := codes.Unknown
+	switch {
+	case skerrors.Find(err, isUnexpectedType) != nil:
+		code = codes.InvalidArgument
+	case skerrors.IsRetryable(err):
+		code = codes.Unavailable
+	case isAggregate(err):
+		code = codes.Internal
+	}
+	st := status.New(code, err.Error())
+	withDetails, derr := st.WithDetails(detailsFor(err)...)
+	if derr != nil {
+		return st
+	}
+	return withDetails
+}
+
+// FromStatus reconstructs the error(s) attached to st as details, falling
+// back to a plain error built from st.Message() if st carries no details
+// produced by ToStatus.  It returns nil if st is nil or reports codes.OK.
+func FromStatus(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+	details := st.Details()
+	if len(details) == 0 {
+		return skerrors.New(st.Message())
+	}
+	errs := make([]error, 0, len(details))
+	for _, d := range details {
+		s, ok := d.(*structpb.Struct)
+		if !ok {
+			continue
+		}
+		errs = append(errs, errorFromDetail(s))
+	}
+	if len(errs) == 0 {
+		return skerrors.New(st.Message())
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return skerrors.Aggregate(errs...)
+}
+
+func isAggregate(err error) bool {
+	_, ok := err.(skerrors.Errors)
+	if ok {
+		return true
+	}
+	_, ok = err.(*skerrors.Errors)
+	return ok
+}
+
+func isUnexpectedType(err error) bool {
+	_, ok := err.(*skerrors.UnexpectedType)
+	if ok {
+		return true
+	}
+	_, ok = err.(skerrors.UnexpectedType)
+	return ok
+}
+
+// detailsFor flattens err's graph into one detail per aggregated error (or
+// a single detail if err isn't an aggregate).
+func detailsFor(err error) []legacyproto.Message {
+	var errs []error
+	if u, ok := err.(interface{ Unwrap() []error }); ok && isAggregate(err) {
+		errs = u.Unwrap()
+	} else {
+		errs = []error{err}
+	}
+	details := make([]legacyproto.Message, len(errs))
+	for i, e := range errs {
+		details[i] = detailFor(e)
+	}
+	return details
+}
+
+// detailFor serializes a single error node into a structpb.Struct carrying
+// its message, cause, context, and resolved stack frames, so it can be
+// attached to a status.Status via WithDetails.
+func detailFor(err error) *structpb.Struct {
+	fields := map[string]interface{}{
+		"message": err.Error(),
+	}
+	var e skerrors.Error
+	if skerrors.As(err, &e) {
+		fields["message"] = e.Err.Error()
+		if e.Cause != nil {
+			fields["cause"] = e.Cause.Error()
+		}
+		if e.Context != nil {
+			fields["context"] = e.Context.Error()
+		}
+		if frames := e.Frames(); len(frames) > 0 {
+			stack := make([]interface{}, len(frames))
+			for i, frame := range frames {
+				stack[i] = map[string]interface{}{
+					"func": frame.Function,
+					"file": frame.File,
+					"line": float64(frame.Line),
+				}
+			}
+			fields["stack"] = stack
+		}
+	}
+	s, _ := structpb.NewStruct(fields)
+	return s
+}
+
+// errorFromDetail reconstructs an error from a detail produced by
+// detailFor.  There's no way to reconstruct the original program counters
+// on this side of the RPC, so if the detail carried a stack, the result is
+// wrapped in a remoteStack that exposes the same Frames() []runtime.Frame
+// accessor as skerrors.Error, populated from the func/file/line the stack
+// had when it was captured.
+func errorFromDetail(s *structpb.Struct) error {
+	fields := s.GetFields()
+	e := skerrors.Error{Err: skerrors.New(fields["message"].GetStringValue())}
+	if cause, ok := fields["cause"]; ok {
+		e.Cause = skerrors.New(cause.GetStringValue())
+	}
+	if context, ok := fields["context"]; ok {
+		e.Context = skerrors.New(context.GetStringValue())
+	}
+	frames := framesFromValue(fields["stack"])
+	if len(frames) == 0 {
+		return e
+	}
+	return remoteStack{error: e, frames: frames}
+}
+
+func framesFromValue(v *structpb.Value) []runtime.Frame {
+	values := v.GetListValue().GetValues()
+	if len(values) == 0 {
+		return nil
+	}
+	frames := make([]runtime.Frame, len(values))
+	for i, value := range values {
+		fields := value.GetStructValue().GetFields()
+		frames[i] = runtime.Frame{
+			Function: fields["func"].GetStringValue(),
+			File:     fields["file"].GetStringValue(),
+			Line:     int(fields["line"].GetNumberValue()),
+		}
+	}
+	return frames
+}
+
+// remoteStack wraps an error reconstructed from a gRPC status detail with
+// the stack frames it was captured with on the far side of the RPC.
+type remoteStack struct {
+	error
+	frames []runtime.Frame
+}
+
+// Unwrap returns the error reconstructed from the detail.
+func (r remoteStack) Unwrap() error { return r.error }
+
+// Frames reports the frames remoteStack was built with, matching
+// skerrors.Error's accessor so callers don't need to special-case errors
+// that arrived over gRPC.
+func (r remoteStack) Frames() []runtime.Frame { return r.frames }
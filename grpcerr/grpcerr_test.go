@@ -0,0 +1,68 @@
+package grpcerr_test
+
+import (
+	"runtime"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+
+	skerrors "github.com/skillian/errors"
+	"github.com/skillian/errors/grpcerr"
+)
+
+func TestToStatusUnexpectedType(t *testing.T) {
+	t.Parallel()
+	st := grpcerr.ToStatus(skerrors.NewUnexpectedType(0, "oops"))
+	if st.Code() != codes.InvalidArgument {
+		t.Errorf("expected codes.InvalidArgument, got %v", st.Code())
+	}
+}
+
+func TestToStatusRetryable(t *testing.T) {
+	t.Parallel()
+	st := grpcerr.ToStatus(skerrors.MarkRetryable(skerrors.New("flaky")))
+	if st.Code() != codes.Unavailable {
+		t.Errorf("expected codes.Unavailable, got %v", st.Code())
+	}
+}
+
+func TestStatusRoundTrip(t *testing.T) {
+	t.Parallel()
+	original := skerrors.ErrorfWithCause(skerrors.New("cause"), "outer failure")
+	st := grpcerr.ToStatus(original)
+	restored := grpcerr.FromStatus(st)
+	var e skerrors.Error
+	if !skerrors.As(restored, &e) {
+		t.Fatalf("FromStatus should return an error wrapping a skerrors.Error, got %T", restored)
+	}
+	if e.Err.Error() != "outer failure" {
+		t.Errorf("round-tripped message mismatch: %q", e.Err.Error())
+	}
+	if e.Cause == nil || e.Cause.Error() != "cause" {
+		t.Errorf("round-tripped cause mismatch: %v", e.Cause)
+	}
+}
+
+func TestStatusRoundTripPreservesStack(t *testing.T) {
+	t.Parallel()
+	original := skerrors.Errorf("boom")
+	wantFrames := original.Frames()
+	if len(wantFrames) == 0 {
+		t.Fatal("original should have a captured stack to compare against")
+	}
+	restored := grpcerr.FromStatus(grpcerr.ToStatus(original))
+	framer, ok := restored.(interface{ Frames() []runtime.Frame })
+	if !ok {
+		t.Fatalf("restored error should implement Frames(), got %T", restored)
+	}
+	gotFrames := framer.Frames()
+	if len(gotFrames) != len(wantFrames) {
+		t.Fatalf("expected %d frames, got %d", len(wantFrames), len(gotFrames))
+	}
+	for i, want := range wantFrames {
+		got := gotFrames[i]
+		if got.Function != want.Function || got.File != want.File || got.Line != want.Line {
+			t.Errorf("frame %d mismatch: got %+v, want %+v", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package errors
+
+// ErrKind is implemented by sentinel error values that classify what went
+// wrong, e.g. distinguishing a validation failure from a backend outage.
+// It lets callers built on this package (controllers, reconcilers, RPC
+// handlers) switch on the kind of an error without type-asserting every
+// concrete error type they might encounter.
+type ErrKind interface {
+	error
+
+	// ErrKind returns a short, stable string identifying the kind of
+	// error, e.g. "NotFound" or "Unavailable".
+	ErrKind() string
+}
+
+// Retryable is implemented by errors that know whether the operation that
+// produced them is safe to retry.
+type Retryable interface {
+	Retryable() bool
+}
+
+// Temporary is implemented by errors that know whether they represent a
+// transient condition that may clear up on its own.
+type Temporary interface {
+	Temporary() bool
+}
+
+// Find walks the graph of err following Err, Cause, and Context (and, for
+// Errors, every collected error; for other errors, whatever their
+// Unwrap() []error or Unwrap() error returns) and returns the first node
+// for which match returns true.  The error returned by Concurrent.Err
+// carries its collected errors only as message text, so Find does not
+// visit them.  Find returns nil if no node matches.
+func Find(err error, match func(error) bool) error {
+	if err == nil {
+		return nil
+	}
+	if match(err) {
+		return err
+	}
+	switch e := err.(type) {
+	case Error:
+		return findFirst(match, e.Err, e.Cause, e.Context)
+	case *Error:
+		if e == nil {
+			return nil
+		}
+		return findFirst(match, e.Err, e.Cause, e.Context)
+	case Errors:
+		return findFirst(match, e.errors...)
+	case *Errors:
+		if e == nil {
+			return nil
+		}
+		return findFirst(match, e.errors...)
+	}
+	if u, ok := err.(interface{ Unwrap() []error }); ok {
+		return findFirst(match, u.Unwrap()...)
+	}
+	if u, ok := err.(interface{ Unwrap() error }); ok {
+		return Find(u.Unwrap(), match)
+	}
+	return nil
+}
+
+func findFirst(match func(error) bool, errs ...error) error {
+	for _, err := range errs {
+		if found := Find(err, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// FindAs is the generic counterpart to Find: it walks the graph of err and
+// returns the first node whose concrete type is T.
+func FindAs[T error](err error) (T, bool) {
+	var zero T
+	found := Find(err, func(e error) bool {
+		_, ok := e.(T)
+		return ok
+	})
+	if found == nil {
+		return zero, false
+	}
+	return found.(T), true
+}
+
+// IsRetryable reports whether err, or any error in its graph, implements
+// Retryable and reports true.
+func IsRetryable(err error) bool {
+	return Find(err, func(e error) bool {
+		r, ok := e.(Retryable)
+		return ok && r.Retryable()
+	}) != nil
+}
+
+// IsTemporary reports whether err, or any error in its graph, implements
+// Temporary and reports true.
+func IsTemporary(err error) bool {
+	return Find(err, func(e error) bool {
+		t, ok := e.(Temporary)
+		return ok && t.Temporary()
+	}) != nil
+}
+
+// retryableError marks an otherwise ordinary error as safe to retry
+// without requiring its original type to implement Retryable itself.
+type retryableError struct {
+	error
+}
+
+// Retryable implements the Retryable interface.
+func (retryableError) Retryable() bool { return true }
+
+// Unwrap allows Find, errors.Is, and errors.As to see through the wrapper
+// to the original error.
+func (r retryableError) Unwrap() error { return r.error }
+
+// MarkRetryable wraps err so that IsRetryable(err) reports true, without
+// changing err's message, Is/As behavior, or its place in the error graph.
+// MarkRetryable returns nil if err is nil.
+func MarkRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return retryableError{err}
+}
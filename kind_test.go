@@ -0,0 +1,64 @@
+package errors_test
+
+import (
+	"testing"
+
+	skerrors "github.com/skillian/errors"
+)
+
+func TestFindCause(t *testing.T) {
+	t.Parallel()
+	e := skerrors.Error{Err: skerrors.New("outer"), Cause: ErrHelloWorld}
+	found := skerrors.Find(e, func(err error) bool { return err == ErrHelloWorld })
+	if found != ErrHelloWorld {
+		t.Errorf("Find should have found the Cause, got: %v", found)
+	}
+}
+
+func TestFindAcrossAggregate(t *testing.T) {
+	t.Parallel()
+	agg := skerrors.Aggregate(skerrors.New("first"), skerrors.Error{Err: ErrHelloWorld})
+	found := skerrors.Find(agg, func(err error) bool { return err == ErrHelloWorld })
+	if found != ErrHelloWorld {
+		t.Errorf("Find should have found ErrHelloWorld in the aggregate, got: %v", found)
+	}
+}
+
+func TestFindAsUnexpectedType(t *testing.T) {
+	t.Parallel()
+	wrapped := skerrors.Error{Err: skerrors.NewUnexpectedType(0, "")}
+	ut, ok := skerrors.FindAs[*skerrors.UnexpectedType](wrapped)
+	if !ok {
+		t.Fatal("FindAs should have found the *UnexpectedType")
+	}
+	if ut.Expected != 0 {
+		t.Errorf("FindAs returned the wrong node: %+v", ut)
+	}
+}
+
+func TestErrorFrames(t *testing.T) {
+	t.Parallel()
+	e := skerrors.CreateError(ErrHelloWorld, nil, nil, 0)
+	frames := e.Frames()
+	if len(frames) == 0 {
+		t.Fatal("Frames should resolve the captured stack")
+	}
+	if frames[0].Function != "github.com/skillian/errors_test.TestErrorFrames" {
+		t.Errorf("unexpected top frame: %+v", frames[0])
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+	if skerrors.IsRetryable(ErrHelloWorld) {
+		t.Error("plain error should not be retryable")
+	}
+	marked := skerrors.MarkRetryable(ErrHelloWorld)
+	if !skerrors.IsRetryable(marked) {
+		t.Error("MarkRetryable should make IsRetryable report true")
+	}
+	wrapped := skerrors.Error{Err: skerrors.New("outer"), Cause: marked}
+	if !skerrors.IsRetryable(wrapped) {
+		t.Error("IsRetryable should see past Cause to the marked error")
+	}
+}
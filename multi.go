@@ -15,7 +15,7 @@ var _ interface {
 	error
 	As(target interface{}) bool
 	Is(target error) bool
-	Unwrap() error
+	Unwrap() []error
 } = Errors{}
 
 // Aggregate multiple errors together into a single error.  Any nil
@@ -30,6 +30,24 @@ func Aggregate(errs ...error) error {
 	return es
 }
 
+// Join returns an Errors whose Unwrap() []error is exactly the non-nil
+// errors in errs, in order, matching the standard library's errors.Join.
+// Unlike Aggregate, Join does not flatten nested Errors/Errors values
+// found in errs — each is kept as a single child error.  Join returns nil
+// if every error in errs is nil.
+func Join(errs ...error) error {
+	es := Errors{errors: make([]error, 0, len(errs))}
+	for _, err := range errs {
+		if err != nil {
+			es.errors = append(es.errors, err)
+		}
+	}
+	if len(es.errors) == 0 {
+		return nil
+	}
+	return es
+}
+
 func (es *Errors) appendErrors(errs []error) {
 	for _, err := range errs {
 		if err == nil {
@@ -110,10 +128,8 @@ func (es Errors) errorsEqual(errs []error) bool {
 	return true
 }
 
-func (es Errors) Unwrap() error {
-	es2 := Errors{errors: es.errors[1:]}
-	if len(es2.errors) == 0 {
-		return nil
-	}
-	return es2
+// Unwrap returns every error in es, so that errors.Is and errors.As (which
+// understand Unwrap() []error as of Go 1.20) walk all of them natively.
+func (es Errors) Unwrap() []error {
+	return es.errors
 }
@@ -0,0 +1,66 @@
+package errors_test
+
+import (
+	goerrors "errors"
+	"testing"
+
+	skerrors "github.com/skillian/errors"
+)
+
+func TestErrorsIsNestedAggregate(t *testing.T) {
+	t.Parallel()
+	sentinel := goerrors.New("sentinel")
+	inner := skerrors.Aggregate(goerrors.New("unrelated"), sentinel)
+	outer := skerrors.Aggregate(goerrors.New("also unrelated"), inner)
+	if !goerrors.Is(outer, sentinel) {
+		t.Error("errors.Is should find the sentinel through nested aggregates")
+	}
+}
+
+func TestErrorsIsMixedErrorGraph(t *testing.T) {
+	t.Parallel()
+	sentinel := goerrors.New("sentinel")
+	wrapped := skerrors.Error{Err: goerrors.New("outer"), Cause: sentinel}
+	agg := skerrors.Aggregate(goerrors.New("unrelated"), wrapped)
+	if !goerrors.Is(agg, sentinel) {
+		t.Error("errors.Is should find the sentinel through a mixed Errors/Error graph")
+	}
+}
+
+func TestJoinFindsSentinel(t *testing.T) {
+	t.Parallel()
+	sentinel := goerrors.New("sentinel")
+	joined := skerrors.Join(goerrors.New("unrelated"), sentinel)
+	if !goerrors.Is(joined, sentinel) {
+		t.Error("errors.Is should find the sentinel through Join")
+	}
+}
+
+func TestJoinMatchesStdlibUnwrapShape(t *testing.T) {
+	t.Parallel()
+	errA := goerrors.New("a")
+	errB := goerrors.New("b")
+	nested := skerrors.Join(errA, errB)
+	joined := skerrors.Join(nil, nested, nil, errA)
+	unwrapper, ok := joined.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("Join's result should implement Unwrap() []error, got %T", joined)
+	}
+	got := unwrapper.Unwrap()
+	if len(got) != 2 {
+		t.Fatalf("Join should keep exactly its 2 non-nil inputs, got: %v", got)
+	}
+	if _, ok := got[0].(skerrors.Errors); !ok || !goerrors.Is(got[0], errA) || !goerrors.Is(got[0], errB) {
+		t.Errorf("Join should keep nested as a single unflattened child, got: %v", got[0])
+	}
+	if got[1] != errA {
+		t.Errorf("Join should keep errA in its original position, got: %v", got[1])
+	}
+}
+
+func TestJoinAllNilReturnsNil(t *testing.T) {
+	t.Parallel()
+	if err := skerrors.Join(nil, nil); err != nil {
+		t.Errorf("Join of only nils should be nil, got: %v", err)
+	}
+}